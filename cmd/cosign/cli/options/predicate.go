@@ -0,0 +1,72 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"sort"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	slsa01 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.1"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+
+	"github.com/sigstore/cosign/pkg/cosign/attestation"
+)
+
+// Predicate short names, used as the value of the --type flag on `cosign
+// attest` / `cosign verify-attestation` and as the key into PredicateTypeMap.
+const (
+	PredicateCustom    = "custom"
+	PredicateSLSA      = "slsaprovenance"
+	PredicateSPDX      = "spdx"
+	PredicateCycloneDX = "cyclonedx"
+	PredicateVuln      = "vuln"
+	PredicateLink      = "link"
+
+	// PredicateSLSA01 and PredicateSLSA1 give authors a way to pin a policy
+	// to a specific SLSA provenance schema version. PredicateSLSA is kept as
+	// an alias of PredicateSLSA02 for backwards compatibility.
+	PredicateSLSA01 = "slsaprovenance0.1"
+	PredicateSLSA02 = "slsaprovenance0.2"
+	PredicateSLSA1  = "slsaprovenance1"
+)
+
+// PredicateTypeMap maps the short predicate type name to the full URI that
+// appears in an in-toto statement's predicateType field.
+var PredicateTypeMap = map[string]string{
+	PredicateCustom:    attestation.CosignCustomPredicateType,
+	PredicateLink:      in_toto.PredicateLinkV1,
+	PredicateSLSA:      in_toto.PredicateSLSAProvenance,
+	PredicateSLSA01:    slsa01.PredicateSLSAProvenance,
+	PredicateSLSA02:    in_toto.PredicateSLSAProvenance,
+	PredicateSLSA1:     slsa1.PredicateSLSAProvenance,
+	PredicateSPDX:      in_toto.PredicateSPDX,
+	PredicateCycloneDX: attestation.CycloneDXPredicateType,
+	PredicateVuln:      attestation.CosignVulnPredicateType,
+}
+
+// PredicateTypeSlice returns the predicate type short names, sorted for
+// stable display. `cosign attest`/`cosign verify-attestation`'s --type flag
+// uses this for its usage text and to validate the flag value against
+// PredicateTypeMap, so a new entry here (e.g. PredicateCycloneDX) is picked
+// up by both commands with no further change.
+func PredicateTypeSlice() []string {
+	types := make([]string, 0, len(PredicateTypeMap))
+	for t := range PredicateTypeMap {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,89 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestation holds cosign-specific in-toto predicate types that
+// don't (yet) have a canonical home upstream in in-toto-golang.
+package attestation
+
+import (
+	"time"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+)
+
+// CosignCustomPredicateType represents the default predicate type used when
+// an attestation is created without an explicit --type, e.g. via
+// `cosign attest --predicate`.
+const CosignCustomPredicateType = "cosign.sigstore.dev/attestation/v1"
+
+// CosignVulnPredicateType represents the type of CosignVulnPredicate
+const CosignVulnPredicateType = "cosign.sigstore.dev/attestation/vuln/v1"
+
+// CosignVulnStatement is the statement used to store vulnerability scan
+// results in an attestation.
+type CosignVulnStatement struct {
+	in_toto.StatementHeader
+	// Predicate contains type specific metadata.
+	Predicate CosignVulnPredicate `json:"predicate"`
+}
+
+// CosignVulnPredicate is the predicate used with the CosignVulnStatement.
+type CosignVulnPredicate struct {
+	Invocation CosignVulnInvocation `json:"invocation"`
+	Scanner    CosignVulnScanner    `json:"scanner"`
+	Metadata   CosignVulnMetadata   `json:"metadata"`
+}
+
+// CosignVulnInvocation describes the invocation of the vulnerability scan.
+type CosignVulnInvocation struct {
+	Parameters interface{} `json:"parameters"`
+	URI        string      `json:"uri"`
+	EventID    string      `json:"event_id"`
+	BuilderID  string      `json:"builder.id"`
+}
+
+// CosignVulnScanner describes the scanner that produced the results.
+type CosignVulnScanner struct {
+	URI     string       `json:"uri"`
+	Version string       `json:"version"`
+	DB      CosignVulnDB `json:"db"`
+	Result  interface{}  `json:"result"`
+}
+
+// CosignVulnDB describes the vulnerability database used by the scanner.
+type CosignVulnDB struct {
+	URI     string `json:"uri"`
+	Version string `json:"version"`
+}
+
+// CosignVulnMetadata holds metadata about when the scan was run.
+type CosignVulnMetadata struct {
+	ScanStartedOn  time.Time `json:"scanStartedOn"`
+	ScanFinishedOn time.Time `json:"scanFinishedOn"`
+}
+
+// CycloneDXPredicateType represents the CycloneDX SBOM predicate type, as
+// recognized by in-toto-golang.
+const CycloneDXPredicateType = "https://cyclonedx.org/bom"
+
+// CycloneDXStatement is the statement used to store a CycloneDX SBOM as an
+// in-toto predicate. CycloneDX documents are themselves valid JSON objects,
+// so the predicate is decoded into generic JSON values (maps, slices and
+// scalars) rather than a typed struct.
+type CycloneDXStatement struct {
+	in_toto.StatementHeader
+	// Predicate contains the CycloneDX BOM document as generic JSON values.
+	Predicate interface{} `json:"predicate"`
+}
@@ -0,0 +1,38 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"github.com/in-toto/in-toto-golang/in_toto"
+	slsa01 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.1"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+)
+
+// ProvenanceStatementSLSA01 is the definition for an entire provenance
+// statement using the SLSA v0.1 predicate, which in-toto-golang does not
+// yet define a typed Statement for.
+type ProvenanceStatementSLSA01 struct {
+	in_toto.StatementHeader
+	Predicate slsa01.ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenanceStatementSLSA1 is the definition for an entire provenance
+// statement using the SLSA v1.0 predicate, which in-toto-golang does not
+// yet define a typed Statement for.
+type ProvenanceStatementSLSA1 struct {
+	in_toto.StatementHeader
+	Predicate slsa1.ProvenancePredicate `json:"predicate"`
+}
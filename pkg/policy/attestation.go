@@ -17,16 +17,12 @@ package policy
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
 	"github.com/in-toto/in-toto-golang/in_toto"
 	"github.com/pkg/errors"
 	"github.com/sigstore/cosign/pkg/oci"
-
-	"github.com/sigstore/cosign/cmd/cosign/cli/options"
-	"github.com/sigstore/cosign/pkg/cosign/attestation"
 )
 
 // AttestationToPayloadJSON takes in a verified Attestation (oci.Signature) and
@@ -38,33 +34,20 @@ import (
 //
 // If there's no error, and payload is empty means the predicateType did not
 // match the attestation.
+//
+// predicateType must be registered in defaultRegistry, either because it's
+// one of the types cosign knows about out of the box, or because the
+// embedding process registered it via policy.Register.
 func AttestationToPayloadJSON(ctx context.Context, predicateType string, verifiedAttestation oci.Signature) ([]byte, error) {
 	// Check the predicate up front, no point in wasting time if it's invalid.
-	predicateURI, ok := options.PredicateTypeMap[predicateType]
+	predicateURI, ok := defaultRegistry.URI(predicateType)
 	if !ok {
 		return nil, fmt.Errorf("invalid predicate type: %s", predicateType)
 	}
 
-	var payloadData map[string]interface{}
-
-	p, err := verifiedAttestation.Payload()
-	if err != nil {
-		return nil, errors.Wrap(err, "getting payload")
-	}
-
-	err = json.Unmarshal(p, &payloadData)
+	decodedPayload, err := decodeDSSEPayload(verifiedAttestation)
 	if err != nil {
-		return nil, errors.Wrap(err, "unmarshaling payload data")
-	}
-
-	var decodedPayload []byte
-	if val, ok := payloadData["payload"]; ok {
-		decodedPayload, err = base64.StdEncoding.DecodeString(val.(string))
-		if err != nil {
-			return nil, errors.Wrap(err, "decoding payload")
-		}
-	} else {
-		return nil, fmt.Errorf("could not find payload in payload data")
+		return nil, err
 	}
 
 	// Only apply the policy against the requested predicate type
@@ -77,54 +60,14 @@ func AttestationToPayloadJSON(ctx context.Context, predicateType string, verifie
 		return nil, nil
 	}
 
-	// NB: In many (all?) of these cases, we could just return the
-	// 'json.Marshal', but we check for errors here to decorate them
-	// with more meaningful error message.
-	var payload []byte
-	switch predicateType {
-	case options.PredicateCustom:
-		payload, err = json.Marshal(statement)
-		if err != nil {
-			return nil, errors.Wrap(err, "generating CosignStatement")
-		}
-	case options.PredicateLink:
-		var linkStatement in_toto.LinkStatement
-		if err := json.Unmarshal(decodedPayload, &linkStatement); err != nil {
-			return nil, errors.Wrap(err, "unmarshaling LinkStatement")
-		}
-		payload, err = json.Marshal(linkStatement)
-		if err != nil {
-			return nil, errors.Wrap(err, "marshaling LinkStatement")
-		}
-	case options.PredicateSLSA:
-		var slsaProvenanceStatement in_toto.ProvenanceStatement
-		if err := json.Unmarshal(decodedPayload, &slsaProvenanceStatement); err != nil {
-			return nil, errors.Wrap(err, "unmarshaling ProvenanceStatement")
-		}
-		payload, err = json.Marshal(slsaProvenanceStatement)
-		if err != nil {
-			return nil, errors.Wrap(err, "marshaling ProvenanceStatement")
-		}
-	case options.PredicateSPDX:
-		var spdxStatement in_toto.SPDXStatement
-		if err := json.Unmarshal(decodedPayload, &spdxStatement); err != nil {
-			return nil, errors.Wrap(err, "unmarshaling SPDXStatement")
-		}
-		payload, err = json.Marshal(spdxStatement)
-		if err != nil {
-			return nil, errors.Wrap(err, "marshaling SPDXStatement")
-		}
-	case options.PredicateVuln:
-		var vulnStatement attestation.CosignVulnStatement
-		if err := json.Unmarshal(decodedPayload, &vulnStatement); err != nil {
-			return nil, errors.Wrap(err, "unmarshaling CosignVulnStatement")
-		}
-		payload, err = json.Marshal(vulnStatement)
-		if err != nil {
-			return nil, errors.Wrap(err, "marshaling CosignVulnStatement")
-		}
-	default:
-		return nil, fmt.Errorf("unsupported predicate type: %s", predicateType)
+	typedStatement, err := defaultRegistry.Handle(predicateType, decodedPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(typedStatement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling %s statement", predicateType)
 	}
 	return payload, nil
 }
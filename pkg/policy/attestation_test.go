@@ -0,0 +1,141 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/pkg/cosign/bundle"
+
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+)
+
+// fakeSignature is a minimal oci.Signature backed by a fixed DSSE envelope,
+// used to drive AttestationToPayloadJSON/AttestationsToPayloadJSON without
+// needing a real signed attestation.
+type fakeSignature struct {
+	payload []byte
+}
+
+func (f *fakeSignature) Payload() ([]byte, error) { return f.payload, nil }
+
+func (f *fakeSignature) Annotations() (map[string]string, error) { return nil, nil }
+func (f *fakeSignature) Base64Signature() (string, error)        { return "", nil }
+func (f *fakeSignature) Cert() (*x509.Certificate, error)         { return nil, nil }
+func (f *fakeSignature) Chain() ([]*x509.Certificate, error)      { return nil, nil }
+func (f *fakeSignature) Bundle() (*bundle.RekorBundle, error)     { return nil, nil }
+func (f *fakeSignature) RFC3161Timestamp() (*bundle.RFC3161Timestamp, error) {
+	return nil, nil
+}
+
+func (f *fakeSignature) Digest() (v1.Hash, error)            { return v1.Hash{}, nil }
+func (f *fakeSignature) DiffID() (v1.Hash, error)             { return v1.Hash{}, nil }
+func (f *fakeSignature) Compressed() (io.ReadCloser, error)   { return nil, nil }
+func (f *fakeSignature) Uncompressed() (io.ReadCloser, error) { return nil, nil }
+func (f *fakeSignature) Size() (int64, error)                 { return int64(len(f.payload)), nil }
+func (f *fakeSignature) MediaType() (types.MediaType, error)  { return "", nil }
+
+// dsseEnvelope marshals statement as the in-toto payload of a (fake, unsigned)
+// DSSE envelope in the shape decodeDSSEPayload expects.
+func dsseEnvelope(t *testing.T, statement interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("marshaling statement: %v", err)
+	}
+	env := map[string]interface{}{
+		"payloadType": "application/vnd.in-toto+json",
+		"payload":     base64.StdEncoding.EncodeToString(b),
+		"signatures":  []interface{}{},
+	}
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("marshaling envelope: %v", err)
+	}
+	return envBytes
+}
+
+func unmarshalOrFatal(t *testing.T, b []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatalf("unmarshaling result: %v", err)
+	}
+}
+
+func TestAttestationToPayloadJSON(t *testing.T) {
+	tests := []struct {
+		predicateType string
+	}{
+		{options.PredicateCustom},
+		{options.PredicateLink},
+		{options.PredicateSPDX},
+		{options.PredicateVuln},
+		{options.PredicateCycloneDX},
+	}
+	for _, tc := range tests {
+		t.Run(tc.predicateType, func(t *testing.T) {
+			uri := options.PredicateTypeMap[tc.predicateType]
+			statement := map[string]interface{}{
+				"predicateType": uri,
+				"predicate":     map[string]interface{}{},
+			}
+			sig := &fakeSignature{payload: dsseEnvelope(t, statement)}
+
+			got, err := AttestationToPayloadJSON(context.Background(), tc.predicateType, sig)
+			if err != nil {
+				t.Fatalf("AttestationToPayloadJSON() returned error: %v", err)
+			}
+			if len(got) == 0 {
+				t.Fatalf("expected non-empty payload for predicate type %s", tc.predicateType)
+			}
+			var got2 map[string]interface{}
+			unmarshalOrFatal(t, got, &got2)
+			if got2["predicateType"] != uri {
+				t.Fatalf("predicateType = %v, want %v", got2["predicateType"], uri)
+			}
+		})
+	}
+}
+
+func TestAttestationToPayloadJSON_MismatchedPredicate(t *testing.T) {
+	statement := map[string]interface{}{
+		"predicateType": options.PredicateTypeMap[options.PredicateSPDX],
+		"predicate":     map[string]interface{}{},
+	}
+	sig := &fakeSignature{payload: dsseEnvelope(t, statement)}
+
+	got, err := AttestationToPayloadJSON(context.Background(), options.PredicateVuln, sig)
+	if err != nil {
+		t.Fatalf("AttestationToPayloadJSON() returned error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected nil payload for a non-matching predicate type, got %s", got)
+	}
+}
+
+func TestAttestationToPayloadJSON_InvalidPredicateType(t *testing.T) {
+	sig := &fakeSignature{payload: dsseEnvelope(t, map[string]interface{}{})}
+	if _, err := AttestationToPayloadJSON(context.Background(), "bogus", sig); err == nil {
+		t.Fatal("expected an error for an unregistered predicate type")
+	}
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/pkg/oci"
+)
+
+// decodeDSSEPayload pulls the base64-encoded in-toto payload out of the
+// DSSE envelope returned by verifiedAttestation.Payload().
+func decodeDSSEPayload(verifiedAttestation oci.Signature) ([]byte, error) {
+	var payloadData map[string]interface{}
+
+	p, err := verifiedAttestation.Payload()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting payload")
+	}
+
+	if err := json.Unmarshal(p, &payloadData); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling payload data")
+	}
+
+	val, ok := payloadData["payload"]
+	if !ok {
+		return nil, fmt.Errorf("could not find payload in payload data")
+	}
+	decodedPayload, err := base64.StdEncoding.DecodeString(val.(string))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding payload")
+	}
+	return decodedPayload, nil
+}
+
+// AttestationsToPayloadJSON evaluates atts against predicateTypes in a single
+// pass, decoding each attestation's DSSE envelope and reading its
+// predicateType exactly once regardless of how many predicateTypes are
+// requested. The typed handler unmarshal then runs only for each requested
+// short name whose URI matches that predicateType. This avoids the N*M
+// decode/unmarshal cost of calling AttestationToPayloadJSON once per
+// (attestation, predicate type) pair when a policy needs to evaluate several
+// predicate types over many attestations.
+//
+// The returned map is keyed by predicate type (the same short names accepted
+// by AttestationToPayloadJSON); each value is the marshaled JSON for every
+// attestation whose predicateType matched, in the order the attestations
+// were given. Attestations whose predicate type isn't one of predicateTypes
+// are skipped. predicateTypes must all be registered in defaultRegistry.
+//
+// Multiple short names may share the same predicate URI (e.g.
+// options.PredicateSLSA and options.PredicateSLSA02 are both aliases of the
+// SLSA v0.2 URI) - every requested short name whose URI matches gets the
+// attestation's payload, so none of them is silently left empty.
+func AttestationsToPayloadJSON(ctx context.Context, predicateTypes []string, atts []oci.Signature) (map[string][][]byte, error) {
+	uriToShortNames := make(map[string][]string, len(predicateTypes))
+	for _, predicateType := range predicateTypes {
+		predicateURI, ok := defaultRegistry.URI(predicateType)
+		if !ok {
+			return nil, fmt.Errorf("invalid predicate type: %s", predicateType)
+		}
+		uriToShortNames[predicateURI] = append(uriToShortNames[predicateURI], predicateType)
+	}
+
+	results := make(map[string][][]byte, len(predicateTypes))
+	for _, att := range atts {
+		decodedPayload, err := decodeDSSEPayload(att)
+		if err != nil {
+			return nil, err
+		}
+
+		var statement in_toto.Statement
+		if err := json.Unmarshal(decodedPayload, &statement); err != nil {
+			return nil, fmt.Errorf("unmarshal in-toto statement: %w", err)
+		}
+
+		shortNames, ok := uriToShortNames[statement.PredicateType]
+		if !ok {
+			// Not one of the predicate types the caller asked for.
+			continue
+		}
+
+		for _, predicateType := range shortNames {
+			typedStatement, err := defaultRegistry.Handle(predicateType, decodedPayload)
+			if err != nil {
+				return nil, err
+			}
+
+			payload, err := json.Marshal(typedStatement)
+			if err != nil {
+				return nil, errors.Wrapf(err, "marshaling %s statement", predicateType)
+			}
+			results[predicateType] = append(results[predicateType], payload)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,87 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/pkg/oci"
+)
+
+func TestAttestationsToPayloadJSON(t *testing.T) {
+	spdxURI := options.PredicateTypeMap[options.PredicateSPDX]
+	vulnURI := options.PredicateTypeMap[options.PredicateVuln]
+
+	atts := []oci.Signature{
+		&fakeSignature{payload: dsseEnvelope(t, map[string]interface{}{
+			"predicateType": spdxURI,
+			"predicate":     map[string]interface{}{},
+		})},
+		&fakeSignature{payload: dsseEnvelope(t, map[string]interface{}{
+			"predicateType": vulnURI,
+			"predicate":     map[string]interface{}{},
+		})},
+		&fakeSignature{payload: dsseEnvelope(t, map[string]interface{}{
+			"predicateType": spdxURI,
+			"predicate":     map[string]interface{}{},
+		})},
+	}
+
+	results, err := AttestationsToPayloadJSON(context.Background(), []string{options.PredicateSPDX, options.PredicateVuln}, atts)
+	if err != nil {
+		t.Fatalf("AttestationsToPayloadJSON() returned error: %v", err)
+	}
+	if got := len(results[options.PredicateSPDX]); got != 2 {
+		t.Fatalf("len(results[spdx]) = %d, want 2", got)
+	}
+	if got := len(results[options.PredicateVuln]); got != 1 {
+		t.Fatalf("len(results[vuln]) = %d, want 1", got)
+	}
+}
+
+// TestAttestationsToPayloadJSON_AliasedShortNames verifies that when two
+// requested short names resolve to the same predicate URI (PredicateSLSA and
+// PredicateSLSA02 are both the SLSA v0.2 URI), a matching attestation
+// populates results for both, instead of one silently overwriting the other.
+func TestAttestationsToPayloadJSON_AliasedShortNames(t *testing.T) {
+	slsaURI := options.PredicateTypeMap[options.PredicateSLSA]
+
+	atts := []oci.Signature{
+		&fakeSignature{payload: dsseEnvelope(t, map[string]interface{}{
+			"predicateType": slsaURI,
+			"predicate":     map[string]interface{}{},
+		})},
+	}
+
+	results, err := AttestationsToPayloadJSON(context.Background(), []string{options.PredicateSLSA, options.PredicateSLSA02}, atts)
+	if err != nil {
+		t.Fatalf("AttestationsToPayloadJSON() returned error: %v", err)
+	}
+	if got := len(results[options.PredicateSLSA]); got != 1 {
+		t.Fatalf("len(results[%s]) = %d, want 1", options.PredicateSLSA, got)
+	}
+	if got := len(results[options.PredicateSLSA02]); got != 1 {
+		t.Fatalf("len(results[%s]) = %d, want 1", options.PredicateSLSA02, got)
+	}
+}
+
+func TestAttestationsToPayloadJSON_InvalidPredicateType(t *testing.T) {
+	if _, err := AttestationsToPayloadJSON(context.Background(), []string{"bogus"}, nil); err == nil {
+		t.Fatal("expected an error for an unregistered predicate type")
+	}
+}
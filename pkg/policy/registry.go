@@ -0,0 +1,135 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/pkg/errors"
+
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/pkg/cosign/attestation"
+)
+
+// PredicateHandler unmarshals a decoded in-toto predicate payload into the
+// typed value that will ultimately be fed (as JSON) to a policy engine.
+type PredicateHandler func(decodedPayload []byte) (interface{}, error)
+
+// predicateRegistration pairs a predicate's full URI with the handler used
+// to turn its payload into a typed statement.
+type predicateRegistration struct {
+	uri     string
+	handler PredicateHandler
+}
+
+// PredicateRegistry lets callers register new predicate types - including
+// ones cosign has no built-in knowledge of - so that AttestationToPayloadJSON
+// and AttestationsToPayloadJSON can dispatch to them without a hardcoded
+// switch statement. This allows downstream projects (e.g. Tekton Chains) to
+// plug in custom predicate schemas without forking cosign.
+type PredicateRegistry struct {
+	registrations map[string]predicateRegistration
+}
+
+// NewPredicateRegistry returns a PredicateRegistry pre-populated with the
+// predicate types cosign knows about out of the box.
+func NewPredicateRegistry() *PredicateRegistry {
+	r := &PredicateRegistry{registrations: map[string]predicateRegistration{}}
+	r.registerDefaults()
+	return r
+}
+
+// Register associates shortName (the value accepted by `cosign attest
+// --type`/`cosign verify-attestation --type`) with predicateURI (the value
+// expected in the in-toto statement's predicateType field) and the handler
+// used to unmarshal a matching payload.
+func (r *PredicateRegistry) Register(shortName, predicateURI string, handler PredicateHandler) {
+	r.registrations[shortName] = predicateRegistration{uri: predicateURI, handler: handler}
+}
+
+// URI returns the predicate URI registered for shortName.
+func (r *PredicateRegistry) URI(shortName string) (string, bool) {
+	reg, ok := r.registrations[shortName]
+	return reg.uri, ok
+}
+
+// Handle looks up the handler registered for shortName and invokes it with
+// decodedPayload.
+func (r *PredicateRegistry) Handle(shortName string, decodedPayload []byte) (interface{}, error) {
+	reg, ok := r.registrations[shortName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported predicate type: %s", shortName)
+	}
+	return reg.handler(decodedPayload)
+}
+
+// defaultRegistry is used by AttestationToPayloadJSON and
+// AttestationsToPayloadJSON.
+var defaultRegistry = NewPredicateRegistry()
+
+// Register adds shortName to the registry used by AttestationToPayloadJSON
+// and AttestationsToPayloadJSON, associating it with predicateURI (the value
+// expected in an in-toto statement's predicateType field) and the handler
+// used to unmarshal a matching payload. This is how downstream projects
+// (e.g. Tekton Chains) plug in predicate schemas cosign has no built-in
+// knowledge of - VEX, VSA, SCAI, or anything else - without forking cosign,
+// and how `cosign attest --type=<custom-uri>` ends up accepting arbitrary
+// predicate URIs registered by the embedding process.
+func Register(shortName, predicateURI string, handler PredicateHandler) {
+	defaultRegistry.Register(shortName, predicateURI, handler)
+}
+
+// unmarshalHandler returns a PredicateHandler that unmarshals into a fresh
+// value produced by newStatement on every call, so concurrent callers don't
+// clobber each other's results.
+func unmarshalHandler(newStatement func() interface{}, name string) PredicateHandler {
+	return func(decodedPayload []byte) (interface{}, error) {
+		v := newStatement()
+		if err := json.Unmarshal(decodedPayload, v); err != nil {
+			return nil, errors.Wrapf(err, "unmarshaling %s", name)
+		}
+		return v, nil
+	}
+}
+
+func (r *PredicateRegistry) registerDefaults() {
+	r.Register(options.PredicateCustom, options.PredicateTypeMap[options.PredicateCustom],
+		func(decodedPayload []byte) (interface{}, error) {
+			var statement in_toto.Statement
+			if err := json.Unmarshal(decodedPayload, &statement); err != nil {
+				return nil, errors.Wrap(err, "unmarshaling in-toto Statement")
+			}
+			return statement, nil
+		})
+	r.Register(options.PredicateLink, options.PredicateTypeMap[options.PredicateLink],
+		unmarshalHandler(func() interface{} { return &in_toto.LinkStatement{} }, "LinkStatement"))
+	r.Register(options.PredicateSLSA, options.PredicateTypeMap[options.PredicateSLSA],
+		unmarshalHandler(func() interface{} { return &in_toto.ProvenanceStatement{} }, "ProvenanceStatement"))
+	r.Register(options.PredicateSLSA01, options.PredicateTypeMap[options.PredicateSLSA01],
+		unmarshalHandler(func() interface{} { return &attestation.ProvenanceStatementSLSA01{} }, "ProvenanceStatementSLSA01"))
+	r.Register(options.PredicateSLSA02, options.PredicateTypeMap[options.PredicateSLSA02],
+		unmarshalHandler(func() interface{} { return &in_toto.ProvenanceStatement{} }, "ProvenanceStatement"))
+	r.Register(options.PredicateSLSA1, options.PredicateTypeMap[options.PredicateSLSA1],
+		unmarshalHandler(func() interface{} { return &attestation.ProvenanceStatementSLSA1{} }, "ProvenanceStatementSLSA1"))
+	r.Register(options.PredicateSPDX, options.PredicateTypeMap[options.PredicateSPDX],
+		unmarshalHandler(func() interface{} { return &in_toto.SPDXStatement{} }, "SPDXStatement"))
+	r.Register(options.PredicateCycloneDX, options.PredicateTypeMap[options.PredicateCycloneDX],
+		unmarshalHandler(func() interface{} { return &attestation.CycloneDXStatement{} }, "CycloneDXStatement"))
+	r.Register(options.PredicateVuln, options.PredicateTypeMap[options.PredicateVuln],
+		unmarshalHandler(func() interface{} { return &attestation.CosignVulnStatement{} }, "CosignVulnStatement"))
+}
@@ -0,0 +1,60 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRegisterPluggability verifies that Register actually mutates the
+// registry AttestationToPayloadJSON reads from, so embedding processes can
+// add predicate types cosign has no built-in knowledge of.
+func TestRegisterPluggability(t *testing.T) {
+	const (
+		shortName = "vex"
+		uri       = "https://openvex.dev/ns/v0.2.0"
+	)
+
+	type vexStatement struct {
+		PredicateType string `json:"predicateType"`
+		Predicate     struct {
+			Handled bool `json:"handled"`
+		} `json:"predicate"`
+	}
+
+	Register(shortName, uri, func(decodedPayload []byte) (interface{}, error) {
+		var statement vexStatement
+		statement.Predicate.Handled = true
+		return statement, nil
+	})
+
+	statement := map[string]interface{}{
+		"predicateType": uri,
+		"predicate":     map[string]interface{}{},
+	}
+	sig := &fakeSignature{payload: dsseEnvelope(t, statement)}
+
+	got, err := AttestationToPayloadJSON(context.Background(), shortName, sig)
+	if err != nil {
+		t.Fatalf("AttestationToPayloadJSON() returned error: %v", err)
+	}
+	var result vexStatement
+	unmarshalOrFatal(t, got, &result)
+	if !result.Predicate.Handled {
+		t.Fatalf("expected the handler registered via Register to run, got %+v", result)
+	}
+}
@@ -0,0 +1,77 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+)
+
+// TestAttestationToPayloadJSON_SLSAVersions verifies that each SLSA
+// provenance predicate type is matched against its own URI and dispatched to
+// its own statement type, rather than all versions being collapsed onto the
+// v0.2 in_toto.ProvenanceStatement.
+func TestAttestationToPayloadJSON_SLSAVersions(t *testing.T) {
+	tests := []struct {
+		predicateType string
+	}{
+		{options.PredicateSLSA},
+		{options.PredicateSLSA01},
+		{options.PredicateSLSA02},
+		{options.PredicateSLSA1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.predicateType, func(t *testing.T) {
+			uri := options.PredicateTypeMap[tc.predicateType]
+			statement := map[string]interface{}{
+				"predicateType": uri,
+				"predicate":     map[string]interface{}{},
+			}
+			sig := &fakeSignature{payload: dsseEnvelope(t, statement)}
+
+			got, err := AttestationToPayloadJSON(context.Background(), tc.predicateType, sig)
+			if err != nil {
+				t.Fatalf("AttestationToPayloadJSON() returned error: %v", err)
+			}
+			var result map[string]interface{}
+			unmarshalOrFatal(t, got, &result)
+			if result["predicateType"] != uri {
+				t.Fatalf("predicateType = %v, want %v", result["predicateType"], uri)
+			}
+		})
+	}
+}
+
+// TestAttestationToPayloadJSON_SLSAVersionMismatch verifies that asking for
+// one SLSA predicate version doesn't match an attestation carrying a
+// different version's URI.
+func TestAttestationToPayloadJSON_SLSAVersionMismatch(t *testing.T) {
+	statement := map[string]interface{}{
+		"predicateType": options.PredicateTypeMap[options.PredicateSLSA1],
+		"predicate":     map[string]interface{}{},
+	}
+	sig := &fakeSignature{payload: dsseEnvelope(t, statement)}
+
+	got, err := AttestationToPayloadJSON(context.Background(), options.PredicateSLSA01, sig)
+	if err != nil {
+		t.Fatalf("AttestationToPayloadJSON() returned error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected nil payload when requesting v0.1 against a v1 attestation, got %s", got)
+	}
+}